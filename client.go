@@ -0,0 +1,36 @@
+package main
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/go-github/v60/github"
+	"github.com/gregjones/httpcache"
+	"github.com/gregjones/httpcache/diskcache"
+	"golang.org/x/oauth2"
+)
+
+var (
+	githubRPS   = getFloatEnv("GITHUB_RPS", 2)
+	githubBurst = int(getFloatEnv("GITHUB_BURST", 5))
+)
+
+// newGithubClient builds the GitHub API client. Its transport chain adds
+// OAuth auth, an on-disk HTTP cache so unchanged resources (conditional
+// GETs via If-Modified-Since/ETag) come back as 304s and don't count
+// against rate limit quota, and a token-bucket limiter with backoff so a
+// large account doesn't trip GitHub's primary or secondary rate limits.
+func newGithubClient(ctx context.Context) *github.Client {
+	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: githubToken})
+	oauthTransport := &oauth2.Transport{Source: ts}
+
+	cached := &httpcache.Transport{
+		Transport:           oauthTransport,
+		Cache:               diskcache.New(cacheDir),
+		MarkCachedResponses: true,
+	}
+
+	limited := newRateLimitedTransport(cached, githubRPS, githubBurst)
+
+	return github.NewClient(&http.Client{Transport: limited})
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v60/github"
+	"gopkg.in/yaml.v3"
+)
+
+// SourceConfig configures which repos from a GitHub owner (user or org)
+// are synced, and how their issues map onto tududi projects/tags/priority.
+// Leaving Owner blank means "the authenticated user plus every org they
+// belong to", filtered by ExcludeOrgs.
+type SourceConfig struct {
+	Owner           string            `yaml:"owner"`
+	Include         []string          `yaml:"include"`
+	Exclude         []string          `yaml:"exclude"`
+	ExcludeOrgs     []string          `yaml:"exclude_orgs"`
+	LabelsInclude   []string          `yaml:"labels_include"`
+	LabelsExclude   []string          `yaml:"labels_exclude"`
+	AssigneeOnly    bool              `yaml:"assignee_only"`
+	Since           string            `yaml:"since"`
+	LabelToPriority map[string]string `yaml:"label_to_priority"`
+	ProjectOverride string            `yaml:"project_override"`
+	TagTemplate     []string          `yaml:"tag_template"`
+}
+
+// Config is the top-level declarative sync configuration, loaded from
+// YAML. The global env vars at the top of main.go still apply (and take
+// precedence as overrides); Config only governs what gets synced and how.
+type Config struct {
+	Sources []SourceConfig `yaml:"sources"`
+}
+
+// compiledSource is a SourceConfig with its regex/duration fields
+// pre-parsed, so filtering doesn't recompile a pattern per issue. The
+// zero value is a valid "allow everything, default routing" source, used
+// when no config file is supplied.
+type compiledSource struct {
+	SourceConfig
+	include []*regexp.Regexp
+	exclude []*regexp.Regexp
+	since   time.Duration
+}
+
+var configPath = flag.String("config", getEnv("CONFIG", ""), "path to YAML sync config")
+
+func loadConfig(path string) (*Config, error) {
+	if path == "" {
+		return &Config{}, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return &cfg, nil
+}
+
+func compileSources(cfg *Config) ([]compiledSource, error) {
+	if len(cfg.Sources) == 0 {
+		return []compiledSource{{}}, nil
+	}
+
+	sources := make([]compiledSource, 0, len(cfg.Sources))
+	for _, s := range cfg.Sources {
+		cs := compiledSource{SourceConfig: s}
+
+		for _, pat := range s.Include {
+			re, err := regexp.Compile(pat)
+			if err != nil {
+				return nil, fmt.Errorf("source %q: bad include pattern %q: %w", s.Owner, pat, err)
+			}
+			cs.include = append(cs.include, re)
+		}
+		for _, pat := range s.Exclude {
+			re, err := regexp.Compile(pat)
+			if err != nil {
+				return nil, fmt.Errorf("source %q: bad exclude pattern %q: %w", s.Owner, pat, err)
+			}
+			cs.exclude = append(cs.exclude, re)
+		}
+
+		if s.Since != "" {
+			d, err := parseSince(s.Since)
+			if err != nil {
+				return nil, fmt.Errorf("source %q: bad since %q: %w", s.Owner, s.Since, err)
+			}
+			cs.since = d
+		}
+
+		sources = append(sources, cs)
+	}
+	return sources, nil
+}
+
+// parseSince accepts everything time.ParseDuration does, plus a "Nd" day
+// suffix, since that's the natural unit for a config like "since: 30d".
+func parseSince(s string) (time.Duration, error) {
+	if strings.HasSuffix(s, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(s, "d"))
+		if err != nil {
+			return 0, err
+		}
+		return time.Duration(days) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(s)
+}
+
+func (s compiledSource) repoAllowed(repoName string) bool {
+	if len(s.include) > 0 {
+		matched := false
+		for _, re := range s.include {
+			if re.MatchString(repoName) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	for _, re := range s.exclude {
+		if re.MatchString(repoName) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s compiledSource) labelsAllowed(labels []*github.Label) bool {
+	names := make(map[string]bool, len(labels))
+	for _, l := range labels {
+		names[strings.ToLower(l.GetName())] = true
+	}
+
+	if len(s.LabelsInclude) > 0 {
+		matched := false
+		for _, want := range s.LabelsInclude {
+			if names[strings.ToLower(want)] {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	for _, skip := range s.LabelsExclude {
+		if names[strings.ToLower(skip)] {
+			return false
+		}
+	}
+	return true
+}
+
+// priorityFor returns the configured priority for the first matching
+// label, or "" if none match (meaning: fall back to default heuristics).
+func (s compiledSource) priorityFor(labels []*github.Label) string {
+	for _, l := range labels {
+		if p, ok := s.LabelToPriority[strings.ToLower(l.GetName())]; ok {
+			return p
+		}
+	}
+	return ""
+}
+
+// tags renders TagTemplate with {repo} substitution, falling back to the
+// hardcoded [repoName, "github"] pair when no template is configured.
+func (s compiledSource) tags(repoName string) []Tag {
+	names := s.TagTemplate
+	if len(names) == 0 {
+		names = []string{repoName, "github"}
+	}
+	tags := make([]Tag, 0, len(names))
+	for _, n := range names {
+		tags = append(tags, Tag{Name: strings.ReplaceAll(n, "{repo}", repoName)})
+	}
+	return tags
+}
+
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/google/go-github/v60/github"
+)
+
+func mustCompileSource(t *testing.T, sc SourceConfig) compiledSource {
+	t.Helper()
+	sources, err := compileSources(&Config{Sources: []SourceConfig{sc}})
+	if err != nil {
+		t.Fatalf("compileSources: %v", err)
+	}
+	return sources[0]
+}
+
+func TestRepoAllowed(t *testing.T) {
+	tests := []struct {
+		name     string
+		sc       SourceConfig
+		repoName string
+		want     bool
+	}{
+		{"no filters allows everything", SourceConfig{}, "any-repo", true},
+		{"include matches", SourceConfig{Include: []string{"^api-"}}, "api-gateway", true},
+		{"include does not match", SourceConfig{Include: []string{"^api-"}}, "web-app", false},
+		{"exclude matches", SourceConfig{Exclude: []string{"^archive-"}}, "archive-old", false},
+		{"exclude does not match", SourceConfig{Exclude: []string{"^archive-"}}, "api-gateway", true},
+		{"exclude wins over include", SourceConfig{Include: []string{".*"}, Exclude: []string{"^api-"}}, "api-gateway", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cs := mustCompileSource(t, tt.sc)
+			if got := cs.repoAllowed(tt.repoName); got != tt.want {
+				t.Errorf("repoAllowed(%q) = %v, want %v", tt.repoName, got, tt.want)
+			}
+		})
+	}
+}
+
+func label(name string) *github.Label {
+	return &github.Label{Name: github.String(name)}
+}
+
+func TestLabelsAllowed(t *testing.T) {
+	tests := []struct {
+		name   string
+		sc     SourceConfig
+		labels []*github.Label
+		want   bool
+	}{
+		{"no filters allows everything", SourceConfig{}, nil, true},
+		{"include matches case-insensitively", SourceConfig{LabelsInclude: []string{"Bug"}}, []*github.Label{label("bug")}, true},
+		{"include does not match", SourceConfig{LabelsInclude: []string{"bug"}}, []*github.Label{label("feature")}, false},
+		{"exclude matches", SourceConfig{LabelsExclude: []string{"wontfix"}}, []*github.Label{label("wontfix")}, false},
+		{"exclude does not match", SourceConfig{LabelsExclude: []string{"wontfix"}}, []*github.Label{label("bug")}, true},
+		{"exclude wins over include", SourceConfig{LabelsInclude: []string{"bug"}, LabelsExclude: []string{"bug"}}, []*github.Label{label("bug")}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cs := mustCompileSource(t, tt.sc)
+			if got := cs.labelsAllowed(tt.labels); got != tt.want {
+				t.Errorf("labelsAllowed(%v) = %v, want %v", tt.labels, got, tt.want)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"flag"
 	"fmt"
 	"io"
 	"log"
@@ -11,22 +12,32 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/go-github/v60/github"
-	"golang.org/x/oauth2"
+	"golang.org/x/sync/errgroup"
 )
 
 // --- Configuration ---
 var (
-	githubToken  = os.Getenv("GITHUB_TOKEN")
-	tududiURL    = strings.TrimRight(os.Getenv("TUDUDI_URL"), "/")
-	tududiAPIKey = os.Getenv("TUDUDI_API_KEY")
-	syncInterval = os.Getenv("SYNC_INTERVAL")
-	dryRun       = os.Getenv("DRY_RUN") == "true"
-	debugMode    = os.Getenv("DEBUG") == "true" // NEW: Enable verbose logs
+	githubToken   = os.Getenv("GITHUB_TOKEN")
+	tududiURL     = strings.TrimRight(os.Getenv("TUDUDI_URL"), "/")
+	tududiAPIKey  = os.Getenv("TUDUDI_API_KEY")
+	syncInterval  = os.Getenv("SYNC_INTERVAL")
+	dryRun        = os.Getenv("DRY_RUN") == "true"
+	debugMode     = os.Getenv("DEBUG") == "true" // NEW: Enable verbose logs
+	stateFilePath = getEnv("STATE_PATH", "sync_state.json")
+	cacheDir      = getEnv("CACHE_DIR", ".gh-cache")
 )
 
+func getEnv(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
+
 const (
 	StatusNotStarted = 0
 	StatusInProgress = 1
@@ -51,6 +62,7 @@ type Task struct {
 	Status    int    `json:"status"`
 	ProjectID int    `json:"project_id"`
 	UID       string `json:"uid,omitempty"`
+	Note      string `json:"note,omitempty"`
 }
 
 func main() {
@@ -72,25 +84,89 @@ func main() {
 	if debugMode {
 		log.Println("🐛 DEBUG MODE ENABLED 🐛")
 	}
+	log.Printf("Sync direction: %s", syncDirection)
+
+	state, err := loadState(stateFilePath)
+	if err != nil {
+		log.Fatalf("Error loading state from %s: %v", stateFilePath, err)
+	}
+
+	flag.Parse()
+	cfg, err := loadConfig(*configPath)
+	if err != nil {
+		log.Fatalf("Error loading config: %v", err)
+	}
+	sources, err := compileSources(cfg)
+	if err != nil {
+		log.Fatalf("Error in config: %v", err)
+	}
 
 	ctx := context.Background()
-	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: githubToken})
-	tc := oauth2.NewClient(ctx, ts)
-	ghClient := github.NewClient(tc)
+	ghClient := newGithubClient(ctx)
+
+	// Shared with the webhook server so the ticker loop and incoming
+	// deliveries never mutate SyncState concurrently.
+	stateMu := &sync.Mutex{}
+
+	startWebhookServer(ctx, ghClient, state, stateMu)
 
 	log.Printf("Starting Sync Service. Interval: %d seconds", interval)
 
 	ticker := time.NewTicker(time.Duration(interval) * time.Second)
 	defer ticker.Stop()
 
-	runSync(ctx, ghClient)
+	syncOnce(ctx, ghClient, state, stateMu, sources)
 
 	for range ticker.C {
-		runSync(ctx, ghClient)
+		syncOnce(ctx, ghClient, state, stateMu, sources)
+	}
+}
+
+// syncOnce runs a sync cycle and persists the resulting cursor/dedup state
+// regardless of whether the cycle fully succeeded, so a failed cycle doesn't
+// force a full incremental re-fetch on the next tick.
+func syncOnce(ctx context.Context, gh *github.Client, state *SyncState, stateMu *sync.Mutex, sources []compiledSource) {
+	stateMu.Lock()
+	defer stateMu.Unlock()
+	if pullEnabled() {
+		runSync(ctx, gh, state, sources)
+	}
+	if pushEnabled() {
+		pushTududiChangesToGithub(ctx, gh, state)
+	}
+	if err := state.save(stateFilePath); err != nil {
+		log.Printf("Error saving sync state: %v", err)
 	}
 }
 
-func runSync(ctx context.Context, gh *github.Client) {
+// syncConcurrency bounds how many repos are fetched in parallel.
+var syncConcurrency = int(getFloatEnv("SYNC_CONCURRENCY", 4))
+
+// progressEvery controls how often runSync logs fan-out progress.
+const progressEvery = 10
+
+// repoJob is one (owner, repo) unit of work for the issue-fetch fan-out.
+// since is snapshotted from SyncState before fan-out so worker goroutines
+// never touch the shared state map concurrently with runSync's collector.
+type repoJob struct {
+	owner    string
+	repoName string
+	src      compiledSource
+	myLogin  string
+	since    time.Time
+}
+
+// repoResult is what a repoJob produces: the issues found plus enough
+// context for the collector to fold them into the shared maps.
+type repoResult struct {
+	repoKey string
+	newest  time.Time
+	issues  []*github.Issue
+	src     compiledSource
+	owner   string
+}
+
+func runSync(ctx context.Context, gh *github.Client, state *SyncState, sources []compiledSource) {
 	log.Println("--- Starting Sync Cycle ---")
 
 	user, _, err := gh.Users.Get(ctx, "")
@@ -100,63 +176,221 @@ func runSync(ctx context.Context, gh *github.Client) {
 	}
 	myLogin := user.GetLogin()
 
+	var jobs []repoJob
+	for _, src := range sources {
+		owners, err := ownersForSource(ctx, gh, src, myLogin)
+		if err != nil {
+			log.Printf("Error resolving owners for source %q: %v", src.Owner, err)
+			continue
+		}
+
+		for _, owner := range owners {
+			repos, err := listRepos(ctx, gh, owner, myLogin)
+			if err != nil {
+				log.Printf("Error listing repos for %s: %v", owner, err)
+				continue
+			}
+			for _, repo := range repos {
+				repoName := repo.GetName()
+				if !src.repoAllowed(repoName) {
+					continue
+				}
+				repoKey := fmt.Sprintf("%s/%s", owner, repoName)
+				since := state.Repos[repoKey].LastSeen
+				if since.IsZero() && src.since > 0 {
+					since = time.Now().Add(-src.since)
+				}
+				jobs = append(jobs, repoJob{owner: owner, repoName: repoName, src: src, myLogin: myLogin, since: since})
+			}
+		}
+	}
+
+	concurrency := syncConcurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	log.Printf("Fetching issues for %d repos (concurrency %d)", len(jobs), concurrency)
+
+	jobCh := make(chan repoJob)
+	resultCh := make(chan repoResult, len(jobs))
+
+	g, gctx := errgroup.WithContext(ctx)
+	for i := 0; i < concurrency; i++ {
+		g.Go(func() error {
+			for job := range jobCh {
+				resultCh <- fetchRepoIssues(gctx, gh, job)
+			}
+			return nil
+		})
+	}
+
+	go func() {
+		for _, job := range jobs {
+			jobCh <- job
+		}
+		close(jobCh)
+	}()
+	go func() {
+		g.Wait()
+		close(resultCh)
+	}()
+
 	processedIDs := make(map[int64]bool)
+	sourceForIssue := make(map[int64]compiledSource)
+	ownerForIssue := make(map[int64]string)
 	var issuesToSync []*github.Issue
 
-	// 1. Fetch Issues
-	opts := &github.SearchOptions{Sort: "updated", Order: "desc"}
-	query := fmt.Sprintf("assignee:%s is:issue", myLogin)
-	
-	result, _, err := gh.Search.Issues(ctx, query, opts)
-	if err != nil {
-		log.Printf("Error searching issues: %v", err)
-	} else {
-		count := 0
-		for _, issue := range result.Issues {
-			if count >= 50 { break }
-			if !processedIDs[issue.GetID()] {
-				issuesToSync = append(issuesToSync, issue)
-				processedIDs[issue.GetID()] = true
-				count++
+	done := 0
+	for result := range resultCh {
+		done++
+		if done%progressEvery == 0 {
+			log.Printf("Progress: fetched %d/%d repos", done, len(jobs))
+		}
+
+		if !result.newest.IsZero() {
+			state.Repos[result.repoKey] = RepoState{LastSeen: result.newest}
+		}
+		for _, issue := range result.issues {
+			if processedIDs[issue.GetID()] {
+				continue
 			}
+			issuesToSync = append(issuesToSync, issue)
+			processedIDs[issue.GetID()] = true
+			sourceForIssue[issue.GetID()] = result.src
+			ownerForIssue[issue.GetID()] = result.owner
 		}
 	}
 
-	// 2. Fetch Repos
-	repoOpts := &github.RepositoryListOptions{Type: "owner", ListOptions: github.ListOptions{PerPage: 100}}
-	repos, _, err := gh.Repositories.List(ctx, "", repoOpts)
-	if err != nil {
-		log.Printf("Error listing repos: %v", err)
-	} else {
-		for _, repo := range repos {
-			if repo.GetOwner().GetLogin() == myLogin {
-				issueOpts := &github.IssueListByRepoOptions{
-					State: "all", Sort: "updated", Direction: "desc",
-					ListOptions: github.ListOptions{PerPage: 20},
-				}
-				repoIssues, _, err := gh.Issues.ListByRepo(ctx, myLogin, repo.GetName(), issueOpts)
-				if err != nil {
-					log.Printf("Error getting issues for %s: %v", repo.GetName(), err)
-					continue
-				}
-				for _, issue := range repoIssues {
-					if issue.IsPullRequest() { continue }
-					if !processedIDs[issue.GetID()] {
-						issuesToSync = append(issuesToSync, issue)
-						processedIDs[issue.GetID()] = true
-					}
-				}
+	log.Printf("Processing %d GitHub issues...", len(issuesToSync))
+	syncIssuesToTududi(issuesToSync, state, sourceForIssue, ownerForIssue)
+}
+
+// fetchRepoIssues fully paginates one repo's issues. job.since is a
+// snapshot taken before fan-out, so this touches no shared state and can
+// run concurrently across repos without locking; only runSync's single
+// collector goroutine ever reads or writes state.Repos.
+func fetchRepoIssues(ctx context.Context, gh *github.Client, job repoJob) repoResult {
+	repoKey := fmt.Sprintf("%s/%s", job.owner, job.repoName)
+
+	issueOpts := &github.IssueListByRepoOptions{
+		State: "all", Sort: "updated", Direction: "desc",
+		Since:       job.since,
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	if job.src.AssigneeOnly {
+		issueOpts.Assignee = job.myLogin
+	}
+
+	result := repoResult{repoKey: repoKey, src: job.src, owner: job.owner}
+	for {
+		repoIssues, resp, err := gh.Issues.ListByRepo(ctx, job.owner, job.repoName, issueOpts)
+		if err != nil {
+			log.Printf("Error getting issues for %s: %v", repoKey, err)
+			break
+		}
+		for _, issue := range repoIssues {
+			if issue.IsPullRequest() {
+				continue
+			}
+			if !job.src.labelsAllowed(issue.Labels) {
+				continue
 			}
+			result.issues = append(result.issues, issue)
+			if issue.GetUpdatedAt().After(result.newest) {
+				result.newest = issue.GetUpdatedAt().Time
+			}
+		}
+		if resp == nil || resp.NextPage == 0 {
+			break
 		}
+		issueOpts.Page = resp.NextPage
 	}
+	return result
+}
 
-	log.Printf("Processing %d GitHub issues...", len(issuesToSync))
-	syncIssuesToTududi(issuesToSync)
+// ownersForSource expands a source's Owner into the list of GitHub owners
+// (user/org logins) whose repos it covers. A blank Owner means "the
+// authenticated user plus every org they belong to", minus ExcludeOrgs.
+func ownersForSource(ctx context.Context, gh *github.Client, src compiledSource, myLogin string) ([]string, error) {
+	if src.Owner != "" {
+		return []string{src.Owner}, nil
+	}
+
+	owners := []string{myLogin}
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		orgs, resp, err := gh.Organizations.List(ctx, "", opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, org := range orgs {
+			login := org.GetLogin()
+			if containsFold(src.ExcludeOrgs, login) {
+				continue
+			}
+			owners = append(owners, login)
+		}
+		if resp == nil || resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return owners, nil
 }
 
-func syncIssuesToTududi(issues []*github.Issue) {
+// listRepos fully paginates an owner's repos. When owner is the
+// authenticated user (myLogin), it must go through the authenticated-user
+// listing so private repos are included: gh.Repositories.List(ctx, owner,
+// ...) with a non-empty owner always hits GET /users/{owner}/repos, which
+// is public-only, even for your own login. For any other owner it tries
+// the org endpoint first since the user endpoint 404s for an org login.
+func listRepos(ctx context.Context, gh *github.Client, owner, myLogin string) ([]*github.Repository, error) {
+	if owner == myLogin {
+		userOpts := &github.RepositoryListByAuthenticatedUserOptions{
+			Visibility:  "all",
+			ListOptions: github.ListOptions{PerPage: 100},
+		}
+		return paginateRepos(func(opts github.ListOptions) ([]*github.Repository, *github.Response, error) {
+			userOpts.ListOptions = opts
+			return gh.Repositories.ListByAuthenticatedUser(ctx, userOpts)
+		})
+	}
+
+	orgOpts := &github.RepositoryListByOrgOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	if repos, err := paginateRepos(func(opts github.ListOptions) ([]*github.Repository, *github.Response, error) {
+		orgOpts.ListOptions = opts
+		return gh.Repositories.ListByOrg(ctx, owner, orgOpts)
+	}); err == nil {
+		return repos, nil
+	}
+
+	userOpts := &github.RepositoryListOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	return paginateRepos(func(opts github.ListOptions) ([]*github.Repository, *github.Response, error) {
+		userOpts.ListOptions = opts
+		return gh.Repositories.List(ctx, owner, userOpts)
+	})
+}
+
+func paginateRepos(list func(github.ListOptions) ([]*github.Repository, *github.Response, error)) ([]*github.Repository, error) {
+	var all []*github.Repository
+	opts := github.ListOptions{PerPage: 100}
+	for {
+		repos, resp, err := list(opts)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, repos...)
+		if resp == nil || resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return all, nil
+}
+
+func syncIssuesToTududi(issues []*github.Issue, state *SyncState, sourceForIssue map[int64]compiledSource, ownerForIssue map[int64]string) {
 	// --- FETCH DATA ---
-	
+
 	projects := fetchTududiProjects()
 	projectMap := make(map[string]int)
 	for _, p := range projects {
@@ -164,16 +398,20 @@ func syncIssuesToTududi(issues []*github.Issue) {
 	}
 	log.Printf("Loaded %d existing PROJECTS", len(projects))
 
-	// Fetch Tasks and build deduplication map
+	// Fetch tasks so we can look up current status for issues we've
+	// already mapped to a task (dedup itself is keyed by GitHub node ID).
 	existingTasks := fetchTududiTasks()
-	taskDedupMap := make(map[string]Task)
-
+	taskByID := make(map[int]Task)
+	taskByUID := make(map[string]Task)
+	taskByProjectAndName := make(map[string]Task)
 	for _, t := range existingTasks {
-		// Key: "ProjectID|TaskName"
-		key := fmt.Sprintf("%d|%s", t.ProjectID, normalizeName(t.Name))
-		taskDedupMap[key] = t
+		taskByID[t.ID] = t
+		if t.UID != "" {
+			taskByUID[t.UID] = t
+		}
+		taskByProjectAndName[projectAndNameKey(t.ProjectID, t.Name)] = t
 	}
-	log.Printf("Loaded %d existing TASKS for deduplication", len(existingTasks))
+	log.Printf("Loaded %d existing TASKS", len(existingTasks))
 
 	mockProjectIDCounter := -1
 
@@ -196,15 +434,27 @@ func syncIssuesToTududi(issues []*github.Issue) {
 			repoDesc = fmt.Sprintf("Imported GitHub Repository: %s", repoName)
 		}
 
+		owner := ownerForIssue[issue.GetID()]
+		if owner == "" {
+			owner = repoOwnerLogin(issue)
+		}
+
 		targetStatus := StatusNotStarted
 		if issue.GetState() == "closed" {
 			targetStatus = StatusCompleted
 		}
 
-		// Resolve Project
-		normRepoName := normalizeName(repoName)
+		src := sourceForIssue[issue.GetID()]
+
+		// Resolve Project (a source can force all its issues into one
+		// named project via project_override)
+		projectName := repoName
+		if src.ProjectOverride != "" {
+			projectName = src.ProjectOverride
+		}
+		normRepoName := normalizeName(projectName)
 		projID, exists := projectMap[normRepoName]
-		
+
 		if !exists {
 			projectStatus := "planned"
 			if isArchived {
@@ -212,13 +462,13 @@ func syncIssuesToTududi(issues []*github.Issue) {
 			}
 
 			if dryRun {
-				log.Printf("[DRY RUN] Would create project: '%s'", repoName)
+				log.Printf("[DRY RUN] Would create project: '%s'", projectName)
 				projectMap[normRepoName] = mockProjectIDCounter
 				projID = mockProjectIDCounter
 				mockProjectIDCounter--
 			} else {
-				log.Printf("Project '%s' not found. Creating...", repoName)
-				newID := createTududiProject(repoName, repoDesc, projectStatus)
+				log.Printf("Project '%s' not found. Creating...", projectName)
+				newID := createTududiProject(projectName, repoDesc, projectStatus)
 				if newID != 0 {
 					projectMap[normRepoName] = newID
 					projID = newID
@@ -228,15 +478,38 @@ func syncIssuesToTududi(issues []*github.Issue) {
 			}
 		}
 
-		// Deduplication Check
-		dedupKey := fmt.Sprintf("%d|%s", projID, normalizeName(issue.GetTitle()))
-		
-		if task, found := taskDedupMap[dedupKey]; found {
-			// Found existing task - check status
+		// Deduplication check: by stable GitHub node ID, not by name.
+		nodeID := issue.GetNodeID()
+		task, found := taskByID[state.Issues[nodeID].TaskID]
+
+		if _, mapped := state.Issues[nodeID]; mapped && !found && debugMode {
+			log.Printf("Stale mapping for node %s (task gone), reconciling", nodeID)
+		}
+
+		if !found {
+			// No (or stale) node-ID mapping. Before assuming the issue is
+			// new, reconcile against tasks that already exist tududi-side:
+			// first by the gh:owner/repo#n UID (covers state-file loss),
+			// then by the old ProjectID|name key the previous dedup scheme
+			// used (covers first deploy against an instance the prior
+			// version already populated). Either match self-heals
+			// state.Issues so this is a one-time reconciliation.
+			if owner != "" {
+				task, found = taskByUID[fmt.Sprintf("gh:%s/%s#%d", owner, repoName, issue.GetNumber())]
+			}
+			if !found {
+				task, found = taskByProjectAndName[projectAndNameKey(projID, issue.GetTitle())]
+			}
+			if found && debugMode {
+				log.Printf("Reconciled '%s' to existing task %d without a node mapping", issue.GetTitle(), task.ID)
+			}
+		}
+
+		if found {
 			if debugMode {
 				log.Printf("Dedup match: '%s' (ID: %d, Status: %d, Target: %d)", task.Name, task.ID, task.Status, targetStatus)
 			}
-			
+
 			// If GitHub is Closed (2) and Task is Not Completed (0 or 1)
 			if targetStatus == StatusCompleted && task.Status != StatusCompleted {
 				log.Printf("[UPDATE] Task '%s' marked completed in GitHub.", task.Name)
@@ -245,21 +518,33 @@ func syncIssuesToTududi(issues []*github.Issue) {
 				log.Printf("[UPDATE] Task '%s' re-opened in GitHub.", task.Name)
 				updateTaskStatus(task.ID, StatusNotStarted)
 			}
+			state.Issues[nodeID] = IssueMapping{TaskID: task.ID}
 			continue
-		} else {
-			if debugMode {
-				log.Printf("No dedup match for key: [%s]", dedupKey)
-			}
+		}
+		if debugMode {
+			log.Printf("No dedup match for node: [%s]", nodeID)
 		}
 
 		// Create New
-		createTududiTask(issue, projID, repoName, targetStatus)
-		
-		// Add to local map to prevent duplication within the same run cycle
-		taskDedupMap[dedupKey] = Task{Name: issue.GetTitle(), ProjectID: projID, Status: targetStatus}
+		newID := createTududiTask(issue, projID, repoName, targetStatus, src, owner)
+		if newID != 0 {
+			state.Issues[nodeID] = IssueMapping{TaskID: newID}
+			// Keep local lookups in sync in case of multiple issues mapping within this cycle.
+			newTask := Task{ID: newID, Name: issue.GetTitle(), ProjectID: projID, Status: targetStatus}
+			taskByID[newID] = newTask
+			taskByProjectAndName[projectAndNameKey(projID, issue.GetTitle())] = newTask
+		}
 	}
 }
 
+// projectAndNameKey is the dedup key the previous (pre-node-ID) sync
+// version used. It's kept only as a reconciliation fallback so issues
+// already synced by that version aren't duplicated once state.Issues is
+// empty (first deploy of this version, or state-file loss).
+func projectAndNameKey(projectID int, name string) string {
+	return fmt.Sprintf("%d|%s", projectID, normalizeName(name))
+}
+
 // --- HELPERS ---
 
 func getHeaders() map[string]string {
@@ -269,6 +554,37 @@ func getHeaders() map[string]string {
 	}
 }
 
+// repoOwnerLogin derives a repo owner login for issues that weren't
+// routed through a config source (e.g. the webhook path), where the
+// issue's Repository field is usually unpopulated.
+func repoOwnerLogin(issue *github.Issue) string {
+	if repo := issue.GetRepository(); repo != nil {
+		if login := repo.GetOwner().GetLogin(); login != "" {
+			return login
+		}
+	}
+	if issue.RepositoryURL != nil {
+		parts := strings.Split(*issue.RepositoryURL, "/")
+		if len(parts) >= 2 {
+			return parts[len(parts)-2]
+		}
+	}
+	return ""
+}
+
+// defaultPriority is the fallback heuristic used when a source has no
+// label_to_priority entry matching the issue's labels.
+func defaultPriority(labels []*github.Label) string {
+	priority := "medium"
+	for _, label := range labels {
+		lname := strings.ToLower(label.GetName())
+		if strings.Contains(lname, "urgent") || strings.Contains(lname, "high") {
+			priority = "high"
+		}
+	}
+	return priority
+}
+
 func normalizeName(name string) string {
 	name = strings.ToLower(name)
 	name = strings.ReplaceAll(name, "-", " ")
@@ -325,24 +641,21 @@ func createTududiProject(name, description, status string) int {
 	return result.ID
 }
 
-func createTududiTask(issue *github.Issue, projectID int, repoName string, status int) {
+func createTududiTask(issue *github.Issue, projectID int, repoName string, status int, src compiledSource, owner string) int {
 	if dryRun {
 		log.Printf("[DRY RUN] Would create Task: '%s' [Status: %d]", issue.GetTitle(), status)
-		return
+		return 0
 	}
 
 	note := issue.GetBody()
 	note += fmt.Sprintf("\n\n**GitHub Source**: [Issue #%d](%s)", issue.GetNumber(), issue.GetHTMLURL())
 
-	priority := "medium"
-	for _, label := range issue.Labels {
-		lname := strings.ToLower(label.GetName())
-		if strings.Contains(lname, "urgent") || strings.Contains(lname, "high") {
-			priority = "high"
-		}
+	priority := src.priorityFor(issue.Labels)
+	if priority == "" {
+		priority = defaultPriority(issue.Labels)
 	}
-	
-	tags := []Tag{{Name: repoName}, {Name: "github"}}
+
+	tags := src.tags(repoName)
 
 	task := map[string]interface{}{
 		"name": issue.GetTitle(),
@@ -353,15 +666,23 @@ func createTududiTask(issue *github.Issue, projectID int, repoName string, statu
 		"tags": tags,
 	}
 
+	// Store the GitHub source machine-readably so the push side of
+	// bidirectional sync can resolve a task back to its issue.
+	if owner != "" {
+		task["uid"] = fmt.Sprintf("gh:%s/%s#%d", owner, repoName, issue.GetNumber())
+	}
+
 	if issue.Milestone != nil && issue.Milestone.DueOn != nil {
 		task["due_date"] = issue.Milestone.DueOn.Format(time.RFC3339)
 	}
 
-	// Make request but ignore return body, we just check error
-	err := makeRequest("POST", "/task", task, nil)
-	if err == nil {
-		log.Printf("Created Task: %s [Status: %d]", issue.GetTitle(), status)
+	var result Task
+	err := makeRequest("POST", "/task", task, &result)
+	if err != nil {
+		return 0
 	}
+	log.Printf("Created Task: %s [Status: %d]", issue.GetTitle(), status)
+	return result.ID
 }
 
 func updateTaskStatus(taskID int, status int) {
@@ -374,8 +695,15 @@ func updateTaskStatus(taskID int, status int) {
 	makeRequest("PATCH", endpoint, payload, nil)
 }
 
+var (
+	tududiRPS    = getFloatEnv("TUDUDI_RPS", 10)
+	tududiClient = &http.Client{
+		Timeout:   10 * time.Second,
+		Transport: newRateLimitedTransport(http.DefaultTransport, tududiRPS, int(tududiRPS)),
+	}
+)
+
 func makeRequest(method, endpoint string, body interface{}, target interface{}) error {
-	client := &http.Client{Timeout: 10 * time.Second}
 	var bodyReader io.Reader
 	if body != nil {
 		jsonBytes, _ := json.Marshal(body)
@@ -385,7 +713,7 @@ func makeRequest(method, endpoint string, body interface{}, target interface{})
 	if err != nil { return err }
 	for k, v := range getHeaders() { req.Header.Set(k, v) }
 
-	resp, err := client.Do(req)
+	resp, err := tududiClient.Do(req)
 	if err != nil { return err }
 	defer resp.Body.Close()
 
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-github/v60/github"
+)
+
+// syncDirection controls which way changes flow: "pull" (GitHub ->
+// tududi, the default), "push" (tududi -> GitHub only), or "both".
+var syncDirection = getEnv("SYNC_DIRECTION", "pull")
+
+func pullEnabled() bool { return syncDirection == "pull" || syncDirection == "both" }
+func pushEnabled() bool { return syncDirection == "push" || syncDirection == "both" }
+
+// uidPattern matches the "gh:owner/repo#123" UID createTududiTask stores
+// on tasks it creates, linking a tududi task back to its source issue.
+var uidPattern = regexp.MustCompile(`^gh:([^/]+)/([^#]+)#(\d+)$`)
+
+const ghSyncMarker = "<!-- gh-sync -->"
+
+// pushTududiChangesToGithub walks tududi tasks whose UID identifies a
+// source GitHub issue and mirrors task completion (close/reopen the
+// issue) and any gh-sync note block (post/update a comment) back onto it.
+func pushTududiChangesToGithub(ctx context.Context, gh *github.Client, state *SyncState) {
+	tasks := fetchTududiTasks()
+	log.Printf("Push: scanning %d tududi tasks for GitHub-linked UIDs", len(tasks))
+
+	for _, task := range tasks {
+		m := uidPattern.FindStringSubmatch(task.UID)
+		if m == nil {
+			continue
+		}
+		owner, repo := m[1], m[2]
+		number, err := strconv.Atoi(m[3])
+		if err != nil {
+			continue
+		}
+
+		issue, _, err := gh.Issues.Get(ctx, owner, repo, number)
+		if err != nil {
+			log.Printf("Push: error fetching %s/%s#%d: %v", owner, repo, number, err)
+			continue
+		}
+
+		pushIssueState(ctx, gh, owner, repo, number, issue, task)
+		if body, ok := extractGhSyncBlock(task.Note); ok {
+			pushIssueComment(ctx, gh, state, owner, repo, number, body)
+		}
+	}
+}
+
+func pushIssueState(ctx context.Context, gh *github.Client, owner, repo string, number int, issue *github.Issue, task Task) {
+	desired := "open"
+	if task.Status == StatusCompleted {
+		desired = "closed"
+	}
+	if issue.GetState() == desired {
+		return
+	}
+
+	if dryRun {
+		log.Printf("[DRY RUN] Would set %s/%s#%d state to %s", owner, repo, number, desired)
+		return
+	}
+
+	if _, _, err := gh.Issues.Edit(ctx, owner, repo, number, &github.IssueRequest{State: &desired}); err != nil {
+		log.Printf("Push: error setting %s/%s#%d state to %s: %v", owner, repo, number, desired, err)
+		return
+	}
+	log.Printf("Push: %s/%s#%d -> %s", owner, repo, number, desired)
+}
+
+func pushIssueComment(ctx context.Context, gh *github.Client, state *SyncState, owner, repo string, number int, body string) {
+	key := fmt.Sprintf("%s/%s#%d", owner, repo, number)
+
+	if dryRun {
+		log.Printf("[DRY RUN] Would sync gh-sync comment on %s", key)
+		return
+	}
+
+	if commentID, exists := state.Comments[key]; exists {
+		_, _, err := gh.Issues.EditComment(ctx, owner, repo, commentID, &github.IssueComment{Body: &body})
+		if err == nil {
+			return
+		}
+		log.Printf("Push: existing comment %d on %s gone, recreating: %v", commentID, key, err)
+	}
+
+	comment, _, err := gh.Issues.CreateComment(ctx, owner, repo, number, &github.IssueComment{Body: &body})
+	if err != nil {
+		log.Printf("Push: error posting comment on %s: %v", key, err)
+		return
+	}
+	state.Comments[key] = comment.GetID()
+}
+
+// extractGhSyncBlock returns the content following the <!-- gh-sync -->
+// marker in a task note, which the user edits to push a comment to the
+// source issue.
+func extractGhSyncBlock(note string) (string, bool) {
+	idx := strings.Index(note, ghSyncMarker)
+	if idx == -1 {
+		return "", false
+	}
+	body := strings.TrimSpace(note[idx+len(ghSyncMarker):])
+	if body == "" {
+		return "", false
+	}
+	return body, true
+}
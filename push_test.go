@@ -0,0 +1,60 @@
+package main
+
+import "testing"
+
+func TestUIDPattern(t *testing.T) {
+	tests := []struct {
+		name       string
+		uid        string
+		wantMatch  bool
+		wantOwner  string
+		wantRepo   string
+		wantNumber string
+	}{
+		{"well formed", "gh:octocat/hello-world#42", true, "octocat", "hello-world", "42"},
+		{"missing prefix", "octocat/hello-world#42", false, "", "", ""},
+		{"missing number", "gh:octocat/hello-world#", false, "", "", ""},
+		{"non-numeric number", "gh:octocat/hello-world#abc", false, "", "", ""},
+		{"empty string", "", false, "", "", ""},
+		{"extra trailing text", "gh:octocat/hello-world#42-ish", false, "", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := uidPattern.FindStringSubmatch(tt.uid)
+			if (m != nil) != tt.wantMatch {
+				t.Fatalf("FindStringSubmatch(%q) match = %v, want %v", tt.uid, m != nil, tt.wantMatch)
+			}
+			if !tt.wantMatch {
+				return
+			}
+			if m[1] != tt.wantOwner || m[2] != tt.wantRepo || m[3] != tt.wantNumber {
+				t.Errorf("FindStringSubmatch(%q) = %v, want owner=%s repo=%s number=%s", tt.uid, m[1:], tt.wantOwner, tt.wantRepo, tt.wantNumber)
+			}
+		})
+	}
+}
+
+func TestExtractGhSyncBlock(t *testing.T) {
+	tests := []struct {
+		name     string
+		note     string
+		wantBody string
+		wantOK   bool
+	}{
+		{"marker with body", "Some note\n<!-- gh-sync -->\nFollow-up comment", "Follow-up comment", true},
+		{"no marker", "Some note with no marker", "", false},
+		{"marker with empty body", "Some note\n<!-- gh-sync -->\n   ", "", false},
+		{"marker at start", "<!-- gh-sync -->\nhello", "hello", true},
+		{"multiple markers keeps everything after the first", "<!-- gh-sync -->\nfirst\n<!-- gh-sync -->\nsecond", "first\n<!-- gh-sync -->\nsecond", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, ok := extractGhSyncBlock(tt.note)
+			if ok != tt.wantOK || body != tt.wantBody {
+				t.Errorf("extractGhSyncBlock(%q) = (%q, %v), want (%q, %v)", tt.note, body, ok, tt.wantBody, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"log"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	backoffBase = 1 * time.Second
+	backoffCap  = 5 * time.Minute
+
+	// maxRateLimitRetries bounds the retry loop in RoundTrip. Without a
+	// cap, a non-rate-limit 403 (SAML enforcement, missing OAuth scope, a
+	// blocked repo) would retry forever since it always fails the same
+	// way, wedging the caller permanently.
+	maxRateLimitRetries = 8
+)
+
+// rateLimitedTransport wraps an http.RoundTripper with a token-bucket
+// limiter plus backoff: it reads X-RateLimit-Remaining / X-RateLimit-Reset
+// to preemptively slow down before the budget is exhausted, and retries
+// responses that are actually rate limits (see isRateLimited) with
+// exponential backoff honoring Retry-After (GitHub's secondary/
+// abuse-detection limit always sets this header), up to
+// maxRateLimitRetries. An ordinary 403 is returned to the caller unretried.
+type rateLimitedTransport struct {
+	transport http.RoundTripper
+	limiter   *rate.Limiter
+}
+
+func newRateLimitedTransport(transport http.RoundTripper, rps float64, burst int) *rateLimitedTransport {
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimitedTransport{
+		transport: transport,
+		limiter:   rate.NewLimiter(rate.Limit(rps), burst),
+	}
+}
+
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		if err := t.limiter.Wait(req.Context()); err != nil {
+			return nil, err
+		}
+
+		resp, err := t.transport.RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+
+		if remaining, ok := intHeader(resp.Header, "X-RateLimit-Remaining"); ok && remaining < 10 {
+			if reset, ok := intHeader(resp.Header, "X-RateLimit-Reset"); ok {
+				if wait := time.Until(time.Unix(int64(reset), 0)); wait > 0 {
+					log.Printf("Rate limit nearly exhausted (%d remaining); pausing %s until reset", remaining, wait.Round(time.Second))
+					if err := sleepCtx(req.Context(), wait); err != nil {
+						resp.Body.Close()
+						return nil, err
+					}
+				}
+			}
+		}
+
+		if !isRateLimited(resp) || attempt >= maxRateLimitRetries {
+			return resp, nil
+		}
+
+		wait := retryAfter(resp, attempt)
+		if isAbuseDetection(resp) {
+			log.Printf("Secondary rate limit (abuse detection) hit on %s; pausing %s", req.URL.Path, wait)
+		} else {
+			log.Printf("Rate limited (%d) on %s; retrying in %s (attempt %d/%d)", resp.StatusCode, req.URL.Path, wait, attempt+1, maxRateLimitRetries)
+		}
+		resp.Body.Close()
+		if err := sleepCtx(req.Context(), wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// isRateLimited reports whether resp is an actual rate limit response
+// rather than an ordinary 403 (SAML enforcement, missing OAuth scope, a
+// blocked repo). 429 always means rate limited; 403 only does when the
+// primary budget is visibly exhausted or Retry-After marks a secondary
+// (abuse-detection) limit. Any other 403 is a real error and must be
+// returned to the caller instead of retried.
+func isRateLimited(resp *http.Response) bool {
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	if resp.StatusCode != http.StatusForbidden {
+		return false
+	}
+	if remaining, ok := intHeader(resp.Header, "X-RateLimit-Remaining"); ok && remaining == 0 {
+		return true
+	}
+	return isAbuseDetection(resp)
+}
+
+// isAbuseDetection distinguishes GitHub's secondary (abuse-detection) limit,
+// which always carries Retry-After, from the primary per-hour limit, which
+// doesn't.
+func isAbuseDetection(resp *http.Response) bool {
+	return resp.Header.Get("Retry-After") != ""
+}
+
+// sleepCtx waits for d, returning early with ctx.Err() if ctx is canceled
+// first, so a multi-minute rate-limit pause can't block shutdown.
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func retryAfter(resp *http.Response, attempt int) time.Duration {
+	if ra := resp.Header.Get("Retry-After"); ra != "" {
+		if secs, err := strconv.Atoi(ra); err == nil {
+			return withJitter(time.Duration(secs) * time.Second)
+		}
+	}
+	d := backoffBase * time.Duration(math.Pow(2, float64(attempt)))
+	if d > backoffCap {
+		d = backoffCap
+	}
+	return withJitter(d)
+}
+
+func withJitter(d time.Duration) time.Duration {
+	return d + time.Duration(rand.Int63n(int64(d)/2+1))
+}
+
+func intHeader(h http.Header, key string) (int, bool) {
+	v := h.Get(key)
+	if v == "" {
+		return 0, false
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
+
+func getFloatEnv(key string, def float64) float64 {
+	v := getEnv(key, "")
+	if v == "" {
+		return def
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return def
+	}
+	return f
+}
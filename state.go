@@ -0,0 +1,69 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+)
+
+// RepoState tracks incremental sync progress for a single repository.
+type RepoState struct {
+	LastSeen time.Time `json:"last_seen"`
+}
+
+// IssueMapping links a GitHub issue to the tududi task created for it.
+type IssueMapping struct {
+	TaskID int `json:"task_id"`
+}
+
+// SyncState is the persisted cursor/dedup state. It is keyed by stable
+// GitHub identifiers (repo full name, issue node ID) rather than names,
+// so a rename on either side doesn't break incremental sync or dedup.
+type SyncState struct {
+	Repos    map[string]RepoState    `json:"repos"`    // key: "owner/repo"
+	Issues   map[string]IssueMapping `json:"issues"`   // key: GitHub issue node ID
+	Comments map[string]int64        `json:"comments"` // key: "owner/repo#number", value: GitHub comment ID
+}
+
+func newSyncState() *SyncState {
+	return &SyncState{
+		Repos:    make(map[string]RepoState),
+		Issues:   make(map[string]IssueMapping),
+		Comments: make(map[string]int64),
+	}
+}
+
+// loadState reads the state file at path, returning a fresh empty state
+// if it doesn't exist yet (first run).
+func loadState(path string) (*SyncState, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return newSyncState(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	state := newSyncState()
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, err
+	}
+	if state.Repos == nil {
+		state.Repos = make(map[string]RepoState)
+	}
+	if state.Issues == nil {
+		state.Issues = make(map[string]IssueMapping)
+	}
+	if state.Comments == nil {
+		state.Comments = make(map[string]int64)
+	}
+	return state, nil
+}
+
+func (s *SyncState) save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/google/go-github/v60/github"
+)
+
+var (
+	webhookAddr   = os.Getenv("WEBHOOK_ADDR")
+	webhookSecret = os.Getenv("WEBHOOK_SECRET")
+)
+
+type webhookRepo struct {
+	Name  string `json:"name"`
+	Owner struct {
+		Login string `json:"login"`
+	} `json:"owner"`
+}
+
+type issuesEventPayload struct {
+	Repository webhookRepo `json:"repository"`
+	Issue      struct {
+		Number int `json:"number"`
+	} `json:"issue"`
+}
+
+type milestoneEventPayload struct {
+	Repository webhookRepo `json:"repository"`
+	Milestone  struct {
+		Number int `json:"number"`
+	} `json:"milestone"`
+}
+
+// deliveryDedup guards against GitHub's at-least-once webhook retries
+// reprocessing the same delivery. It's a process-lifetime in-memory set;
+// the GitHub-node-ID dedup in syncIssuesToTududi would catch a missed
+// case anyway, so this only needs to cover the common retry case.
+type deliveryDedup struct {
+	mu   sync.Mutex
+	seen map[string]bool
+}
+
+func newDeliveryDedup() *deliveryDedup {
+	return &deliveryDedup{seen: make(map[string]bool)}
+}
+
+func (d *deliveryDedup) seenBefore(id string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.seen[id] {
+		return true
+	}
+	d.seen[id] = true
+	return false
+}
+
+// startWebhookServer starts the HTTP server handling GitHub webhook
+// deliveries, if WEBHOOK_ADDR is configured. It runs alongside the
+// polling ticker loop in main, which acts as a reconciliation backstop
+// for deliveries that are missed or fail to process. stateMu is shared
+// with that ticker loop so they never mutate SyncState concurrently.
+func startWebhookServer(ctx context.Context, gh *github.Client, state *SyncState, stateMu *sync.Mutex) {
+	if webhookAddr == "" {
+		return
+	}
+	if webhookSecret == "" {
+		log.Fatal("WEBHOOK_SECRET must be set when WEBHOOK_ADDR is configured")
+	}
+
+	dedup := newDeliveryDedup()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/webhook", func(w http.ResponseWriter, r *http.Request) {
+		handleWebhook(ctx, gh, state, stateMu, dedup, w, r)
+	})
+
+	log.Printf("Starting webhook server on %s", webhookAddr)
+	go func() {
+		if err := http.ListenAndServe(webhookAddr, mux); err != nil {
+			log.Fatalf("Webhook server failed: %v", err)
+		}
+	}()
+}
+
+func handleWebhook(ctx context.Context, gh *github.Client, state *SyncState, stateMu *sync.Mutex, dedup *deliveryDedup, w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "error reading body", http.StatusBadRequest)
+		return
+	}
+
+	if !validSignature(r.Header.Get("X-Hub-Signature-256"), body) {
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	if id := r.Header.Get("X-GitHub-Delivery"); id != "" && dedup.seenBefore(id) {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	switch event := r.Header.Get("X-GitHub-Event"); event {
+	case "issues", "issue_comment":
+		var p issuesEventPayload
+		if err := json.Unmarshal(body, &p); err != nil || p.Issue.Number == 0 {
+			log.Printf("Webhook: malformed %s payload: %v", event, err)
+			break
+		}
+		log.Printf("Webhook: syncing %s/%s#%d (%s)", p.Repository.Owner.Login, p.Repository.Name, p.Issue.Number, event)
+		issue, _, err := gh.Issues.Get(ctx, p.Repository.Owner.Login, p.Repository.Name, p.Issue.Number)
+		if err != nil {
+			log.Printf("Webhook: error fetching %s/%s#%d: %v", p.Repository.Owner.Login, p.Repository.Name, p.Issue.Number, err)
+			break
+		}
+		syncAndPersist(stateMu, state, []*github.Issue{issue})
+	case "milestone":
+		var p milestoneEventPayload
+		if err := json.Unmarshal(body, &p); err != nil || p.Milestone.Number == 0 {
+			log.Printf("Webhook: malformed milestone payload: %v", err)
+			break
+		}
+		issues, err := issuesForMilestone(ctx, gh, p.Repository.Owner.Login, p.Repository.Name, p.Milestone.Number)
+		if err != nil {
+			log.Printf("Webhook: error listing issues for %s/%s milestone %d: %v", p.Repository.Owner.Login, p.Repository.Name, p.Milestone.Number, err)
+			break
+		}
+		log.Printf("Webhook: syncing %d issue(s) for %s/%s milestone %d", len(issues), p.Repository.Owner.Login, p.Repository.Name, p.Milestone.Number)
+		syncAndPersist(stateMu, state, issues)
+	default:
+		if debugMode {
+			log.Printf("Webhook: ignoring event %s", event)
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// issuesForMilestone fully paginates a milestone's issues; a milestone
+// with more than one page would otherwise silently sync only its first
+// 100 issues.
+func issuesForMilestone(ctx context.Context, gh *github.Client, owner, repo string, milestone int) ([]*github.Issue, error) {
+	opts := &github.IssueListByRepoOptions{
+		State:       "all",
+		Milestone:   strconv.Itoa(milestone),
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	var all []*github.Issue
+	for {
+		issues, resp, err := gh.Issues.ListByRepo(ctx, owner, repo, opts)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, issues...)
+		if resp == nil || resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return all, nil
+}
+
+func syncAndPersist(stateMu *sync.Mutex, state *SyncState, issues []*github.Issue) {
+	stateMu.Lock()
+	defer stateMu.Unlock()
+	syncIssuesToTududi(issues, state, nil, nil)
+	if err := state.save(stateFilePath); err != nil {
+		log.Printf("Error saving sync state: %v", err)
+	}
+}
+
+func validSignature(header string, body []byte) bool {
+	const prefix = "sha256="
+	if !strings.HasPrefix(header, prefix) {
+		return false
+	}
+	sig, err := hex.DecodeString(strings.TrimPrefix(header, prefix))
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(webhookSecret))
+	mac.Write(body)
+	return hmac.Equal(sig, mac.Sum(nil))
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+)
+
+func sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestValidSignature(t *testing.T) {
+	body := []byte(`{"action":"opened"}`)
+
+	oldSecret := webhookSecret
+	webhookSecret = "s3cr3t"
+	defer func() { webhookSecret = oldSecret }()
+
+	tests := []struct {
+		name   string
+		header string
+		body   []byte
+		want   bool
+	}{
+		{"valid signature", sign(webhookSecret, body), body, true},
+		{"wrong secret", sign("other-secret", body), body, false},
+		{"tampered body", sign(webhookSecret, body), []byte(`{"action":"closed"}`), false},
+		{"missing prefix", hex.EncodeToString([]byte("deadbeef")), body, false},
+		{"non-hex payload", "sha256=not-hex", body, false},
+		{"empty header", "", body, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := validSignature(tt.header, tt.body); got != tt.want {
+				t.Errorf("validSignature(%q) = %v, want %v", tt.header, got, tt.want)
+			}
+		})
+	}
+}